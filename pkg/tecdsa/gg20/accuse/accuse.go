@@ -0,0 +1,129 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package accuse lets a GG20 signer running in identifiable-abort mode
+// produce, and any third party verify, evidence that a specific cosigner
+// misbehaved during a specific signing round. Unlike the opaque
+// "signature failed" errors the rest of the package returns, an Evidence
+// value is self-contained and can be checked offline by someone who was not
+// a participant in the session.
+package accuse
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof"
+)
+
+// SignedMessage is a retained protocol message together with the
+// TranscriptSig the accused attached to it in identifiable-abort mode.
+type SignedMessage struct {
+	Payload []byte
+	Sig     []byte
+}
+
+// Evidence is the minimum set of retained artefacts needed to reproduce a
+// single failing check against AccusedID.
+type Evidence struct {
+	SessionID []byte
+	Round     int
+	AccusedID uint32
+	AccuserID uint32
+
+	// Messages holds the accused's signed broadcasts/P2P sends relevant to
+	// Round, encoded as the accuser received them.
+	Messages []SignedMessage
+
+	// Backend identifies which mta.Backend produced Ciphertext/Proof, so
+	// Verify's caller knows which kind of backend to construct to replay the
+	// well-formedness check.
+	Backend mta.ID
+	// Ciphertext is the accused's MtA ciphertext for this round (e.g.
+	// Round1Bcast.Ctxt).
+	Ciphertext []byte
+	// Proof is the well-formedness proof the accused computed for
+	// Ciphertext against DealerParams (e.g. Round1Bcast.Proof or the
+	// relevant Round1P2PSend.Proof).
+	Proof []byte
+	// DealerParams is the (possibly invalid) ring-Pedersen auxiliary
+	// parameters Proof was computed against, JSON-encoded proof.DealerParams
+	// -- the dealer's in trusted-dealer mode, the accuser's own in DKG mode.
+	DealerParams []byte
+
+	// ReproScript is a human-readable description of the steps Verify took,
+	// included so a human reviewer can sanity-check Verify's output without
+	// re-deriving it.
+	ReproScript string
+}
+
+// Verify replays the checks described by evidence and reports whether
+// AccusedID is guilty. backend, if non-nil, must be configured with the
+// accused's public key (e.g. a *paillier.Backend built around the accused's
+// Paillier public key) and matching evidence.Backend, so Verify can replay
+// the well-formedness proof itself; callers that can't obtain the accused's
+// public key may pass nil to skip that check and rely on DealerParams
+// validation and signature replay alone. verifyTranscriptSig, if non-nil, is
+// used to check any retained message's TranscriptSig before it is trusted as
+// having come from AccusedID; callers that don't run identifiable-abort
+// mode's signature scheme may pass nil to skip that check.
+func Verify(evidence *Evidence, backend mta.Backend, verifyTranscriptSig func(peerID uint32, transcriptHash, sig []byte) bool) (guilty uint32, reason string, err error) {
+	if evidence == nil {
+		return 0, "", fmt.Errorf("accuse: nil evidence")
+	}
+
+	switch evidence.Round {
+	case 1:
+		return verifyRound1(evidence, backend, verifyTranscriptSig)
+	default:
+		return 0, "", fmt.Errorf("accuse: round %d evidence is not yet supported", evidence.Round)
+	}
+}
+
+func verifyRound1(evidence *Evidence, backend mta.Backend, verifyTranscriptSig func(peerID uint32, transcriptHash, sig []byte) bool) (uint32, string, error) {
+	if len(evidence.DealerParams) == 0 {
+		return 0, "", fmt.Errorf("accuse: evidence carries no dealer params to replay")
+	}
+	var dealerParams proof.DealerParams
+	if err := json.Unmarshal(evidence.DealerParams, &dealerParams); err != nil {
+		return 0, "", fmt.Errorf("accuse: decoding dealer params: %w", err)
+	}
+
+	if err := proof.ValidateDealerParams(&dealerParams); err != nil {
+		return evidence.AccusedID, fmt.Sprintf("round 1: dealer params failed structural validation: %v", err), nil
+	}
+	if err := proof.VerifyRingPedersenSetup(&dealerParams, dealerParams.SetupProof); err != nil {
+		return evidence.AccusedID, fmt.Sprintf("round 1: dealer params failed ring-Pedersen setup verification: %v", err), nil
+	}
+
+	if backend != nil {
+		if backend.ID() != evidence.Backend {
+			return 0, "", fmt.Errorf("accuse: backend %d does not match evidence's backend %d", backend.ID(), evidence.Backend)
+		}
+		if len(evidence.Ciphertext) == 0 || len(evidence.Proof) == 0 {
+			return 0, "", fmt.Errorf("accuse: evidence carries no ciphertext/proof to replay")
+		}
+		if err := backend.Verify(evidence.Ciphertext, evidence.Proof, mta.AuxParams(evidence.DealerParams)); err != nil {
+			return evidence.AccusedID, fmt.Sprintf("round 1: well-formedness proof failed to verify: %v", err), nil
+		}
+	}
+
+	if verifyTranscriptSig != nil {
+		for _, msg := range evidence.Messages {
+			// msg.Payload is already the JSON encoding participant.transcriptHash
+			// hashed, so this must match that function's construction exactly --
+			// sessionID || round byte || payload -- or every honestly-signed
+			// message would be rejected.
+			hash := mta.TranscriptHash(evidence.SessionID, evidence.Round, msg.Payload)
+			if !verifyTranscriptSig(evidence.AccusedID, hash, msg.Sig) {
+				return evidence.AccusedID, "round 1: retained message's transcript signature does not verify", nil
+			}
+		}
+	}
+
+	return 0, "no failing check reproduced against the accused party", nil
+}
@@ -0,0 +1,175 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package accuse
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta/cl"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof/testutil"
+)
+
+// dealerParamsPrimeBitLen is shared across this file's tests -- see
+// proof/testutil.HonestDealerParams.
+const dealerParamsPrimeBitLen = 1004
+
+// clGroup builds a GroupOrder whose F has order dividing Q, as mta/cl's
+// well-formedness proof requires: see mta.bench_test.go's benchCLGroup for
+// the same construction.
+func clGroup(t *testing.T) *cl.GroupOrder {
+	t.Helper()
+	var q, modulus *big.Int
+	for {
+		var err error
+		q, err = rand.Prime(rand.Reader, 256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		modulus = new(big.Int).Add(new(big.Int).Mul(q, big.NewInt(2)), big.NewInt(1))
+		if modulus.ProbablyPrime(20) {
+			break
+		}
+	}
+	f := big.NewInt(1)
+	for f.Cmp(big.NewInt(1)) == 0 {
+		h, err := rand.Int(rand.Reader, modulus)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Exp(h, big.NewInt(2), modulus)
+	}
+	gq, err := rand.Int(rand.Reader, modulus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &cl.GroupOrder{Modulus: modulus, GQ: gq, F: f, Q: q}
+}
+
+func clBackend(t *testing.T) *cl.Backend {
+	t.Helper()
+	group := clGroup(t)
+	sk, err := rand.Int(rand.Reader, group.Modulus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := new(big.Int).Exp(group.GQ, sk, group.Modulus)
+	return &cl.Backend{Group: group, Pk: pk, Sk: sk}
+}
+
+func buildEvidence(t *testing.T) (*Evidence, *cl.Backend) {
+	t.Helper()
+	backend := clBackend(t)
+	dealerParams := testutil.HonestDealerParams(t, dealerParamsPrimeBitLen, true)
+	encodedParams, err := json.Marshal(dealerParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := big.NewInt(7)
+	ctxt, r, err := backend.Encrypt(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofBytes, err := backend.ProveWellFormed(value, r, ctxt, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Evidence{
+		SessionID:    []byte("session"),
+		Round:        1,
+		AccusedID:    2,
+		AccuserID:    1,
+		Backend:      backend.ID(),
+		Ciphertext:   ctxt,
+		Proof:        proofBytes,
+		DealerParams: encodedParams,
+		Messages:     []SignedMessage{{Payload: ctxt, Sig: []byte("sig")}},
+	}, backend
+}
+
+func TestVerify_NoFailingCheckOnHonestEvidence(t *testing.T) {
+	evidence, backend := buildEvidence(t)
+	guilty, reason, err := Verify(evidence, backend, nil)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if guilty != 0 {
+		t.Fatalf("expected no guilty party, got %d: %s", guilty, reason)
+	}
+}
+
+func TestVerify_CatchesTamperedProof(t *testing.T) {
+	evidence, backend := buildEvidence(t)
+	tampered := append([]byte{}, evidence.Proof...)
+	tampered[0] ^= 0xFF
+	evidence.Proof = tampered
+
+	guilty, reason, err := Verify(evidence, backend, nil)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if guilty != evidence.AccusedID {
+		t.Fatalf("expected accused party %d to be guilty, got %d: %s", evidence.AccusedID, guilty, reason)
+	}
+}
+
+func TestVerify_CatchesDegenerateDealerParams(t *testing.T) {
+	evidence, backend := buildEvidence(t)
+	degenerate := &proof.DealerParams{NTilde: big.NewInt(15), H1: big.NewInt(2), H2: big.NewInt(7)}
+	encoded, err := json.Marshal(degenerate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evidence.DealerParams = encoded
+
+	guilty, reason, err := Verify(evidence, backend, nil)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if guilty != evidence.AccusedID {
+		t.Fatalf("expected accused party %d to be guilty, got %d: %s", evidence.AccusedID, guilty, reason)
+	}
+}
+
+func TestVerify_RejectsBackendMismatch(t *testing.T) {
+	evidence, backend := buildEvidence(t)
+	evidence.Backend = mta.Paillier
+
+	if _, _, err := Verify(evidence, backend, nil); err == nil {
+		t.Fatal("expected error verifying evidence whose declared backend doesn't match the supplied backend")
+	}
+}
+
+func TestVerify_CatchesForgedTranscriptSig(t *testing.T) {
+	evidence, backend := buildEvidence(t)
+	verifyTranscriptSig := func(peerID uint32, transcriptHash, sig []byte) bool {
+		return string(sig) == "the real sig"
+	}
+
+	guilty, reason, err := Verify(evidence, backend, verifyTranscriptSig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if guilty != evidence.AccusedID {
+		t.Fatalf("expected accused party %d to be guilty, got %d: %s", evidence.AccusedID, guilty, reason)
+	}
+}
+
+func TestVerify_RejectsUnsupportedRound(t *testing.T) {
+	evidence, backend := buildEvidence(t)
+	evidence.Round = 2
+
+	if _, _, err := Verify(evidence, backend, nil); err == nil {
+		t.Fatal("expected error verifying round 2 evidence")
+	}
+}
@@ -0,0 +1,199 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package inactivity implements an accusation/claim protocol for GG20
+// signers, modelled on keep-network's tBTC inactivity subsystem: a signer
+// that doesn't hear from a cosigner by that round's deadline can raise a
+// signed InactivityClaim, other signers counter-sign it against their own
+// view of the round, and a quorum of counter-signatures produces an
+// InactivityCertificate that an external governance layer can act on (e.g.
+// to slash or rotate the offending share).
+package inactivity
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// expectedMessage names the inbound message a cosigner owes for a given
+// round, so a Tracker can report not just "who" but "what was missing" --
+// e.g. a cosigner can deliver Ctxt while still withholding its Range1Proof.
+type expectedMessage int
+
+const (
+	// Round1P2PMessage is the Round1P2PSend.Proof (the well-formedness
+	// proof, a Range1Proof for the Paillier backend) each DKG cosigner owes
+	// every other cosigner in round 1.
+	Round1P2PMessage expectedMessage = iota
+)
+
+// Tracker records, for a single signer running a single round, the deadline
+// and expected-message obligation of each cosigner, and which of them have
+// been satisfied so far. SignRound1 does not create one itself: the caller
+// driving SignRound1 creates one per round via NewTracker (or
+// participant's Signer.NewRound1InactivityTracker), calls MarkReceived as
+// messages arrive, and calls
+// Inactive once the deadline has passed to get the ids that never
+// delivered.
+type Tracker struct {
+	SessionID []byte
+	Round     int
+	Message   expectedMessage
+	Deadline  time.Time
+
+	expected map[uint32]bool
+	received map[uint32]bool
+}
+
+// NewTracker starts tracking cosignerIDs' delivery of message for round,
+// each owed by deadline.
+func NewTracker(sessionID []byte, round int, message expectedMessage, cosignerIDs []uint32, deadline time.Time) *Tracker {
+	expected := make(map[uint32]bool, len(cosignerIDs))
+	for _, id := range cosignerIDs {
+		expected[id] = true
+	}
+	return &Tracker{
+		SessionID: sessionID,
+		Round:     round,
+		Message:   message,
+		Deadline:  deadline,
+		expected:  expected,
+		received:  make(map[uint32]bool, len(cosignerIDs)),
+	}
+}
+
+// MarkReceived records that cosignerID's expected message for this round has
+// arrived.
+func (t *Tracker) MarkReceived(cosignerID uint32) {
+	t.received[cosignerID] = true
+}
+
+// Inactive returns, in ascending order, the tracked cosigner ids that have
+// not yet delivered their expected message and whose deadline has passed as
+// of now.
+func (t *Tracker) Inactive(now time.Time) []uint32 {
+	if now.Before(t.Deadline) {
+		return nil
+	}
+	var ids []uint32
+	for id := range t.expected {
+		if !t.received[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// InactivityClaim accuses InactiveIDs of failing to deliver their round
+// messages by the deadline AccuserID observed, signed by AccuserID's
+// long-term key.
+type InactivityClaim struct {
+	SessionID   []byte
+	Round       int
+	InactiveIDs []uint32
+	AccuserID   uint32
+	Sig         []byte
+}
+
+// TranscriptHash returns the value the claim's Sig (and any counter-sig) is
+// computed over.
+func (c *InactivityClaim) TranscriptHash() []byte {
+	h := sha256.New()
+	h.Write(c.SessionID)
+	writeUint32(h, uint32(c.Round))
+	for _, id := range c.InactiveIDs {
+		writeUint32(h, id)
+	}
+	writeUint32(h, c.AccuserID)
+	return h.Sum(nil)
+}
+
+// NewClaim builds and signs an InactivityClaim.
+func NewClaim(sessionID []byte, round int, accuserID uint32, inactiveIDs []uint32, sign func([]byte) ([]byte, error)) (*InactivityClaim, error) {
+	if len(inactiveIDs) == 0 {
+		return nil, fmt.Errorf("inactivity: no inactive cosigners to claim")
+	}
+	claim := &InactivityClaim{
+		SessionID:   sessionID,
+		Round:       round,
+		InactiveIDs: inactiveIDs,
+		AccuserID:   accuserID,
+	}
+	sig, err := sign(claim.TranscriptHash())
+	if err != nil {
+		return nil, fmt.Errorf("inactivity: signing claim: %w", err)
+	}
+	claim.Sig = sig
+	return claim, nil
+}
+
+// InactivityCertificate is an InactivityClaim plus counter-signatures from a
+// quorum of the other signers, each attesting that their own view of Round
+// agrees the accused ids were inactive.
+type InactivityCertificate struct {
+	Claim       *InactivityClaim
+	CounterSigs map[uint32][]byte // cosigner id -> signature over Claim.TranscriptHash()
+}
+
+// CounterSign lets signerID add its own attestation to a claim it
+// independently verified against its own view of the round.
+func CounterSign(claim *InactivityClaim, signerID uint32, sign func([]byte) ([]byte, error)) ([]byte, error) {
+	sig, err := sign(claim.TranscriptHash())
+	if err != nil {
+		return nil, fmt.Errorf("inactivity: counter-signing claim: %w", err)
+	}
+	return sig, nil
+}
+
+// NewCertificate assembles a certificate from a claim and its gathered
+// counter-signatures. It does not itself enforce a quorum threshold -- that
+// depends on the group's configured t -- callers should use Verify with the
+// appropriate threshold.
+func NewCertificate(claim *InactivityClaim, counterSigs map[uint32][]byte) *InactivityCertificate {
+	return &InactivityCertificate{Claim: claim, CounterSigs: counterSigs}
+}
+
+// Verify checks certificate using verify (signer id, Claim.TranscriptHash(),
+// signature). The accuser's claim signature must verify and counts as one
+// signature toward threshold; each valid counter-signature from a signer
+// other than the accuser counts as one more. It returns an error if the
+// claim signature fails or fewer than threshold signatures verify.
+func Verify(certificate *InactivityCertificate, threshold int, verify func(signerID uint32, msg, sig []byte) bool) error {
+	if certificate == nil || certificate.Claim == nil {
+		return fmt.Errorf("inactivity: nil certificate")
+	}
+	claim := certificate.Claim
+	hash := claim.TranscriptHash()
+
+	if !verify(claim.AccuserID, hash, claim.Sig) {
+		return fmt.Errorf("inactivity: accuser %d's claim signature does not verify", claim.AccuserID)
+	}
+
+	verified := 1 // the accuser's own signature counts
+	for signerID, sig := range certificate.CounterSigs {
+		if signerID == claim.AccuserID {
+			continue
+		}
+		if verify(signerID, hash, sig) {
+			verified++
+		}
+	}
+
+	if verified < threshold {
+		return fmt.Errorf("inactivity: only %d of required %d counter-signatures verified", verified, threshold)
+	}
+	return nil
+}
+
+func writeUint32(h interface{ Write([]byte) (int, error) }, v uint32) {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	h.Write(buf[:])
+}
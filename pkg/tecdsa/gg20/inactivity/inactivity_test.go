@@ -0,0 +1,131 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package inactivity
+
+import (
+	"testing"
+	"time"
+)
+
+func sign(key byte) func([]byte) ([]byte, error) {
+	return func(msg []byte) ([]byte, error) {
+		sig := append([]byte{key}, msg...)
+		return sig, nil
+	}
+}
+
+func verify(keys map[uint32]byte) func(uint32, []byte, []byte) bool {
+	return func(signerID uint32, msg, sig []byte) bool {
+		if len(sig) == 0 || sig[0] != keys[signerID] {
+			return false
+		}
+		want := append([]byte{keys[signerID]}, msg...)
+		if len(want) != len(sig) {
+			return false
+		}
+		for i := range want {
+			if want[i] != sig[i] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func TestTracker_InactiveBeforeDeadline(t *testing.T) {
+	now := time.Unix(1000, 0)
+	deadline := now.Add(time.Minute)
+	tracker := NewTracker([]byte("session"), 1, Round1P2PMessage, []uint32{2, 3}, deadline)
+
+	if ids := tracker.Inactive(now); ids != nil {
+		t.Fatalf("expected no inactive ids before deadline, got %v", ids)
+	}
+}
+
+func TestTracker_InactiveAfterDeadline(t *testing.T) {
+	now := time.Unix(1000, 0)
+	deadline := now.Add(-time.Second)
+	tracker := NewTracker([]byte("session"), 1, Round1P2PMessage, []uint32{2, 3, 4}, deadline)
+	tracker.MarkReceived(3)
+
+	ids := tracker.Inactive(now)
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 4 {
+		t.Fatalf("expected [2 4], got %v", ids)
+	}
+}
+
+func TestTracker_AllReceivedIsNotInactive(t *testing.T) {
+	now := time.Unix(1000, 0)
+	deadline := now.Add(-time.Second)
+	tracker := NewTracker([]byte("session"), 1, Round1P2PMessage, []uint32{2, 3}, deadline)
+	tracker.MarkReceived(2)
+	tracker.MarkReceived(3)
+
+	if ids := tracker.Inactive(now); len(ids) != 0 {
+		t.Fatalf("expected no inactive ids once all delivered, got %v", ids)
+	}
+}
+
+func TestClaimAndCertificate_RoundTrip(t *testing.T) {
+	keys := map[uint32]byte{1: 0xA1, 2: 0xA2, 3: 0xA3}
+
+	claim, err := NewClaim([]byte("session"), 1, 1, []uint32{4}, sign(keys[1]))
+	if err != nil {
+		t.Fatalf("NewClaim: %v", err)
+	}
+
+	counterSigs := map[uint32][]byte{1: claim.Sig}
+	for _, id := range []uint32{2, 3} {
+		sig, err := CounterSign(claim, id, sign(keys[id]))
+		if err != nil {
+			t.Fatalf("CounterSign(%d): %v", id, err)
+		}
+		counterSigs[id] = sig
+	}
+
+	cert := NewCertificate(claim, counterSigs)
+	if err := Verify(cert, 3, verify(keys)); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestClaimAndCertificate_RejectsBelowQuorum(t *testing.T) {
+	keys := map[uint32]byte{1: 0xA1, 2: 0xA2}
+
+	claim, err := NewClaim([]byte("session"), 1, 1, []uint32{4}, sign(keys[1]))
+	if err != nil {
+		t.Fatalf("NewClaim: %v", err)
+	}
+	cert := NewCertificate(claim, map[uint32][]byte{1: claim.Sig})
+
+	if err := Verify(cert, 2, verify(keys)); err == nil {
+		t.Fatal("expected error verifying a certificate without quorum")
+	}
+}
+
+func TestClaimAndCertificate_RejectsForgedCounterSig(t *testing.T) {
+	keys := map[uint32]byte{1: 0xA1, 2: 0xA2}
+
+	claim, err := NewClaim([]byte("session"), 1, 1, []uint32{4}, sign(keys[1]))
+	if err != nil {
+		t.Fatalf("NewClaim: %v", err)
+	}
+	cert := NewCertificate(claim, map[uint32][]byte{
+		1: claim.Sig,
+		2: []byte("not a real signature"),
+	})
+
+	if err := Verify(cert, 2, verify(keys)); err == nil {
+		t.Fatal("expected error verifying a certificate with a forged counter-signature")
+	}
+}
+
+func TestNewClaim_RejectsEmptyInactiveIDs(t *testing.T) {
+	if _, err := NewClaim([]byte("session"), 1, 1, nil, sign(0xA1)); err == nil {
+		t.Fatal("expected error building a claim with no inactive ids")
+	}
+}
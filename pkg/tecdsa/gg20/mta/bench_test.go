@@ -0,0 +1,144 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package mta_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/nerifnetwork/kryptology/pkg/paillier"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta/cl"
+	mtapaillier "github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta/paillier"
+)
+
+// These benchmarks compare the Paillier and CL mta.Backend implementations
+// on the operations both can perform in this tree without a
+// pkg/core/curves instance: Encrypt, plus ProveWellFormed/Verify for CL
+// (Paillier's need a curves.Curve, which this trimmed tree has no
+// constructor for -- see paillier.Backend.Curve). There is no full 6-round
+// signing flow benchmark: this package only implements round 1 (rounds 2-6
+// aren't present anywhere in this tree for either backend to drive), and
+// CL can no longer even start a round-1 session via SignRound1 --
+// Backend.CanCompleteSigning reports false, and SignRound1 refuses it --
+// so a same-session comparison isn't obtainable here at all. Decrypt is
+// omitted for CL for the same reason: it is unimplemented (see mta/cl), so
+// there is nothing to time there.
+//
+// Parameters below use a reduced bit length (smaller than the ~2048-bit
+// Paillier modulus / CL discriminant a real deployment needs) purely so
+// these benchmarks run quickly; they are not a recommendation for
+// production parameter sizes.
+
+const benchBitLen = 256
+
+func benchPaillierBackend(tb testing.TB) mta.Backend {
+	tb.Helper()
+	p, err := rand.Prime(rand.Reader, benchBitLen/2)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	q, err := rand.Prime(rand.Reader, benchBitLen/2)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	n := new(big.Int).Mul(p, q)
+	pk := &paillier.PublicKey{N: n}
+	sk := &paillier.SecretKey{PublicKey: *pk}
+	return &mtapaillier.Backend{Pk: pk, Sk: sk}
+}
+
+// benchCLGroup builds a GroupOrder whose F genuinely has order dividing Q,
+// as the well-formedness proof's "mod Q" exponent arithmetic requires (see
+// mta/cl's wellFormedProof.T): Q is prime, Modulus = 2*Q+1 is prime, and F
+// is a random element raised to the cofactor 2, landing it in the order-Q
+// subgroup.
+func benchCLGroup(tb testing.TB) *cl.GroupOrder {
+	tb.Helper()
+	var q, modulus *big.Int
+	for {
+		var err error
+		q, err = rand.Prime(rand.Reader, benchBitLen)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		modulus = new(big.Int).Add(new(big.Int).Mul(q, big.NewInt(2)), big.NewInt(1))
+		if modulus.ProbablyPrime(20) {
+			break
+		}
+	}
+	f := big.NewInt(1)
+	for f.Cmp(big.NewInt(1)) == 0 {
+		h, err := rand.Int(rand.Reader, modulus)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		f.Exp(h, big.NewInt(2), modulus)
+	}
+	gq, err := rand.Int(rand.Reader, modulus)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return &cl.GroupOrder{Modulus: modulus, GQ: gq, F: f, Q: q}
+}
+
+func benchCLBackend(tb testing.TB) mta.Backend {
+	tb.Helper()
+	group := benchCLGroup(tb)
+	sk, err := rand.Int(rand.Reader, group.Modulus)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	pk := new(big.Int).Exp(group.GQ, sk, group.Modulus)
+
+	return &cl.Backend{Group: group, Pk: pk, Sk: sk}
+}
+
+func BenchmarkPaillierBackend_Encrypt(b *testing.B) {
+	backend := benchPaillierBackend(b)
+	value := big.NewInt(42)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := backend.Encrypt(value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCLBackend_Encrypt(b *testing.B) {
+	backend := benchCLBackend(b)
+	value := big.NewInt(42)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := backend.Encrypt(value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCLBackend_EncryptProveVerify(b *testing.B) {
+	backend := benchCLBackend(b)
+	value := big.NewInt(42)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctxt, r, err := backend.Encrypt(value)
+		if err != nil {
+			b.Fatal(err)
+		}
+		proofBytes, err := backend.ProveWellFormed(value, r, ctxt, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := backend.Verify(ctxt, proofBytes, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
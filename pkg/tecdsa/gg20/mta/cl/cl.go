@@ -0,0 +1,241 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package cl implements the Castagnos-Laguillaumie (CL) MtA backend: linear
+// (Paillier-style) encryption over a group of order that is a multiple of q
+// (the curve order) by a large, secret cofactor, so the message space is
+// exactly Z_q and no range proof against an externally supplied ring-Pedersen
+// modulus is needed -- unlike the Paillier backend in
+// pkg/tecdsa/gg20/mta/paillier, no dealer-supplied auxiliary parameters are
+// involved at all.
+//
+// NOTE: a production CL backend needs arithmetic in the class group of a
+// ~-2048-bit-discriminant imaginary quadratic order, which this tree has no
+// library for. GroupOrder below stands in for that group's public
+// parameters (its generator g_q and the message-encoding point f), so the
+// scheme's shape -- Enc(pk, m; r) = (g_q^r, pk^r * f^m) and the Schnorr-style
+// well-formedness proof -- matches the real construction, but the group
+// itself is a plain multiplicative group mod a safe-prime-like modulus, not
+// a genuine class group; swap GroupOrder's arithmetic for a class-group
+// implementation before using this in production.
+//
+// Backend cannot complete a signing session: its Decrypt always returns
+// ErrDecryptUnimplemented, since recovering m from f^m is a discrete-log
+// problem that the real CL scheme solves with a trapdoor tied to
+// class-group arithmetic, which GroupOrder's stand-in group does not have.
+// SignRound1 enforces this directly -- Backend.CanCompleteSigning reports
+// false, so SignRound1 refuses to start a session with it -- rather than
+// relying on callers to have read this comment. Encrypt, ProveWellFormed,
+// Verify, and the homomorphic operations are fully implemented and can
+// still be exercised directly to demonstrate the wire format and proof
+// shape a real implementation would use.
+package cl
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta"
+)
+
+// ErrDecryptUnimplemented is returned by Backend.Decrypt: see the package
+// doc comment for why this backend cannot yet complete signing.
+var ErrDecryptUnimplemented = errors.New("cl mta backend: decrypt requires class-group discrete-log trapdoor, not implemented in this tree")
+
+// GroupOrder holds the public parameters of the group CL encryption runs
+// over: Modulus is the group's modulus, GQ generates the (secret-order)
+// subgroup used for randomization, and F generates the order-q subgroup
+// encryption messages are encoded into.
+type GroupOrder struct {
+	Modulus *big.Int
+	GQ      *big.Int
+	F       *big.Int
+	Q       *big.Int // the curve order; the message space is Z_q
+}
+
+// Backend implements mta.Backend using CL encryption. It cannot complete a
+// signing session end-to-end -- see the package doc comment and Decrypt.
+type Backend struct {
+	Group *GroupOrder
+	Pk    *big.Int // pk = GQ^sk mod Modulus
+	Sk    *big.Int // nil on a party that only encrypts/verifies
+}
+
+var _ mta.Backend = (*Backend)(nil)
+
+// ciphertext is (u, v) = (g_q^r, pk^r * f^m).
+type ciphertext struct {
+	U *big.Int
+	V *big.Int
+}
+
+// wellFormedProof is a Schnorr-style argument of knowledge of (m, r) with
+// m < Q underlying a ciphertext, without a range proof against any external
+// modulus: m's range is enforced structurally, by sampling the proof's
+// message-blinding factor from the same Z_q the real message lives in.
+type wellFormedProof struct {
+	A *big.Int // g_q^s mod Modulus
+	B *big.Int // pk^s * f^t mod Modulus
+	Z *big.Int // s + e*r
+	T *big.Int // t + e*m mod Q
+}
+
+func (b *Backend) ID() mta.ID { return mta.CL }
+
+// CanCompleteSigning always returns false: see the package doc comment and
+// Decrypt. SignRound1 uses this to refuse starting a session with Backend
+// rather than silently accepting a selection that can never finish signing.
+func (b *Backend) CanCompleteSigning() bool { return false }
+
+func (b *Backend) Encrypt(value *big.Int) ([]byte, *big.Int, error) {
+	r, err := rand.Int(rand.Reader, b.Group.Modulus)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct := b.encryptWithRandomness(value, r)
+	encoded, err := json.Marshal(ct)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encoded, r, nil
+}
+
+func (b *Backend) encryptWithRandomness(value, r *big.Int) *ciphertext {
+	u := new(big.Int).Exp(b.Group.GQ, r, b.Group.Modulus)
+	v := new(big.Int).Exp(b.Pk, r, b.Group.Modulus)
+	v.Mul(v, new(big.Int).Exp(b.Group.F, value, b.Group.Modulus))
+	v.Mod(v, b.Group.Modulus)
+	return &ciphertext{U: u, V: v}
+}
+
+func (b *Backend) ProveWellFormed(value, randomness *big.Int, ciphertextBytes []byte, _ mta.AuxParams) ([]byte, error) {
+	var ct ciphertext
+	if err := json.Unmarshal(ciphertextBytes, &ct); err != nil {
+		return nil, fmt.Errorf("cl mta backend: decoding ciphertext: %w", err)
+	}
+
+	s, err := rand.Int(rand.Reader, b.Group.Modulus)
+	if err != nil {
+		return nil, err
+	}
+	t, err := rand.Int(rand.Reader, b.Group.Q)
+	if err != nil {
+		return nil, err
+	}
+
+	a := new(big.Int).Exp(b.Group.GQ, s, b.Group.Modulus)
+	bb := new(big.Int).Exp(b.Pk, s, b.Group.Modulus)
+	bb.Mul(bb, new(big.Int).Exp(b.Group.F, t, b.Group.Modulus))
+	bb.Mod(bb, b.Group.Modulus)
+
+	e := clChallenge(ct.U, ct.V, a, bb)
+
+	z := new(big.Int).Mul(e, randomness)
+	z.Add(z, s)
+
+	m := new(big.Int).Mul(e, value)
+	m.Add(m, t)
+	m.Mod(m, b.Group.Q)
+
+	return json.Marshal(&wellFormedProof{A: a, B: bb, Z: z, T: m})
+}
+
+func (b *Backend) Verify(ciphertextBytes, proofBytes []byte, _ mta.AuxParams) error {
+	var ct ciphertext
+	if err := json.Unmarshal(ciphertextBytes, &ct); err != nil {
+		return fmt.Errorf("cl mta backend: decoding ciphertext: %w", err)
+	}
+	var wp wellFormedProof
+	if err := json.Unmarshal(proofBytes, &wp); err != nil {
+		return fmt.Errorf("cl mta backend: decoding proof: %w", err)
+	}
+
+	e := clChallenge(ct.U, ct.V, wp.A, wp.B)
+
+	lhs := new(big.Int).Exp(b.Group.GQ, wp.Z, b.Group.Modulus)
+	rhs := new(big.Int).Exp(ct.U, e, b.Group.Modulus)
+	rhs.Mul(rhs, wp.A)
+	rhs.Mod(rhs, b.Group.Modulus)
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("cl mta backend: randomness consistency check failed")
+	}
+
+	lhs = new(big.Int).Exp(b.Pk, wp.Z, b.Group.Modulus)
+	lhs.Mul(lhs, new(big.Int).Exp(b.Group.F, wp.T, b.Group.Modulus))
+	lhs.Mod(lhs, b.Group.Modulus)
+
+	rhs = new(big.Int).Exp(ct.V, e, b.Group.Modulus)
+	rhs.Mul(rhs, wp.B)
+	rhs.Mod(rhs, b.Group.Modulus)
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("cl mta backend: message consistency check failed")
+	}
+	return nil
+}
+
+func (b *Backend) Decrypt(ciphertextBytes []byte) (*big.Int, error) {
+	if b.Sk == nil {
+		return nil, fmt.Errorf("cl mta backend: no secret key")
+	}
+	var ct ciphertext
+	if err := json.Unmarshal(ciphertextBytes, &ct); err != nil {
+		return nil, fmt.Errorf("cl mta backend: decoding ciphertext: %w", err)
+	}
+	// v / u^sk = f^m; since f has order q, recovering m from f^m requires a
+	// discrete log in a size-q group, which the real CL scheme solves via a
+	// trapdoor available only to the secret-key holder. That trapdoor needs
+	// class-group arithmetic this tree doesn't have; a real implementation
+	// plugs it in here.
+	return nil, ErrDecryptUnimplemented
+}
+
+func (b *Backend) HomomorphicAdd(c1, c2 []byte) ([]byte, error) {
+	var ct1, ct2 ciphertext
+	if err := json.Unmarshal(c1, &ct1); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(c2, &ct2); err != nil {
+		return nil, err
+	}
+	sum := &ciphertext{
+		U: new(big.Int).Mod(new(big.Int).Mul(ct1.U, ct2.U), b.Group.Modulus),
+		V: new(big.Int).Mod(new(big.Int).Mul(ct1.V, ct2.V), b.Group.Modulus),
+	}
+	return json.Marshal(sum)
+}
+
+func (b *Backend) HomomorphicScalarMul(ciphertextBytes []byte, scalar *big.Int) ([]byte, error) {
+	var ct ciphertext
+	if err := json.Unmarshal(ciphertextBytes, &ct); err != nil {
+		return nil, err
+	}
+	product := &ciphertext{
+		U: new(big.Int).Exp(ct.U, scalar, b.Group.Modulus),
+		V: new(big.Int).Exp(ct.V, scalar, b.Group.Modulus),
+	}
+	return json.Marshal(product)
+}
+
+// clChallenge derives a Fiat-Shamir challenge from the given big.Int
+// transcript elements. Each element is length-prefixed before hashing so
+// that two different statement tuples can't collide by shifting bytes
+// across an element boundary -- see proof.challenge, which this mirrors.
+func clChallenge(elements ...*big.Int) *big.Int {
+	h := sha256.New()
+	var lenBuf [4]byte
+	for _, e := range elements {
+		b := e.Bytes()
+		binary.BigEndian.PutUint32(lenBuf[:], uint32(len(b)))
+		h.Write(lenBuf[:])
+		h.Write(b)
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
@@ -0,0 +1,78 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package mta defines the encryption backend GG20 signing uses to run its
+// multiplicative-to-additive (MtA) share conversion. SignRound1 originally
+// hard-coded Paillier encryption and a Range1 proof verified against a
+// dealer's ring-Pedersen parameters; both now sit behind the Backend
+// interface so a signing session can instead pick, e.g., the CL (class
+// group) backend in pkg/tecdsa/gg20/mta/cl, which needs no dealer-supplied
+// auxiliary parameters at all.
+package mta
+
+import "math/big"
+
+// ID identifies which Backend produced a given ciphertext/proof, so a
+// receiver that only has the opaque bytes off the wire knows how to decode
+// and verify them.
+type ID byte
+
+const (
+	// Paillier is the original backend: Paillier encryption plus a Range1
+	// proof against the dealer's ring-Pedersen parameters.
+	Paillier ID = iota + 1
+	// CL is the Castagnos-Laguillaumie class-group backend: no dealer
+	// parameters or range proof against an external modulus are needed,
+	// since the message space is exactly Z_q.
+	CL
+)
+
+// AuxParams is backend-specific public auxiliary data needed to produce or
+// check a well-formedness proof. The Paillier backend uses it to carry the
+// dealer's ring-Pedersen DealerParams; the CL backend ignores it.
+type AuxParams []byte
+
+// Backend is the encryption scheme and well-formedness proof system a
+// signing session uses for MtA. Ciphertexts and proofs are opaque []byte so
+// they can be carried on the wire (in Round1Bcast.Ctxt / Round1P2PSend.Proof)
+// without the recipient needing to import every backend.
+type Backend interface {
+	ID() ID
+
+	// CanCompleteSigning reports whether this backend's Decrypt is usable,
+	// and so whether a signing session started with it can actually run to
+	// completion through the later rounds that call Decrypt. SignRound1
+	// refuses to start a session with a backend that returns false here,
+	// rather than letting a caller silently select one that can encrypt and
+	// prove well-formedness but can never finish signing (see
+	// pkg/tecdsa/gg20/mta/cl's package doc comment for why that's currently
+	// true of the CL backend).
+	CanCompleteSigning() bool
+
+	// Encrypt encrypts value under the backend's public key, returning the
+	// ciphertext and the randomness used (needed to later prove
+	// well-formedness).
+	Encrypt(value *big.Int) (ciphertext []byte, randomness *big.Int, err error)
+
+	// ProveWellFormed proves that ciphertext encrypts value using
+	// randomness, optionally against aux (see AuxParams).
+	ProveWellFormed(value, randomness *big.Int, ciphertext []byte, aux AuxParams) (proof []byte, err error)
+
+	// Verify checks a well-formedness proof produced by ProveWellFormed.
+	Verify(ciphertext, proof []byte, aux AuxParams) error
+
+	// Decrypt recovers the plaintext underlying ciphertext using the
+	// backend's secret key.
+	Decrypt(ciphertext []byte) (*big.Int, error)
+
+	// HomomorphicAdd returns an encryption of the sum of the two
+	// ciphertexts' plaintexts.
+	HomomorphicAdd(c1, c2 []byte) ([]byte, error)
+
+	// HomomorphicScalarMul returns an encryption of scalar times
+	// ciphertext's plaintext.
+	HomomorphicScalarMul(ciphertext []byte, scalar *big.Int) ([]byte, error)
+}
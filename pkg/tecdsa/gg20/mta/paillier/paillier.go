@@ -0,0 +1,109 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package paillier is the original GG20 MtA backend: Paillier encryption,
+// with well-formedness shown via a Range1 proof against the dealer's
+// ring-Pedersen parameters.
+package paillier
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/nerifnetwork/kryptology/pkg/core/curves"
+	"github.com/nerifnetwork/kryptology/pkg/paillier"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof"
+)
+
+// Backend implements mta.Backend using Paillier encryption.
+type Backend struct {
+	Curve curves.Curve
+	Pk    *paillier.PublicKey
+	Sk    *paillier.SecretKey // nil on a party that only encrypts/verifies
+}
+
+var _ mta.Backend = (*Backend)(nil)
+
+func (b *Backend) ID() mta.ID { return mta.Paillier }
+
+func (b *Backend) CanCompleteSigning() bool { return true }
+
+func (b *Backend) Encrypt(value *big.Int) ([]byte, *big.Int, error) {
+	ctxt, r, err := b.Pk.Encrypt(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctxt.Bytes(), r, nil
+}
+
+func (b *Backend) ProveWellFormed(value, randomness *big.Int, ciphertext []byte, aux mta.AuxParams) ([]byte, error) {
+	dealerParams, err := decodeAux(aux)
+	if err != nil {
+		return nil, err
+	}
+	pp := proof.Proof1Params{
+		Curve:        b.Curve,
+		Pk:           b.Pk,
+		A:            value,
+		C:            new(big.Int).SetBytes(ciphertext),
+		R:            randomness,
+		DealerParams: dealerParams,
+	}
+	rangeProof, err := pp.Prove()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rangeProof)
+}
+
+func (b *Backend) Verify(ciphertext, proofBytes []byte, aux mta.AuxParams) error {
+	dealerParams, err := decodeAux(aux)
+	if err != nil {
+		return err
+	}
+	var rangeProof proof.Range1Proof
+	if err := json.Unmarshal(proofBytes, &rangeProof); err != nil {
+		return fmt.Errorf("paillier mta backend: decoding range1 proof: %w", err)
+	}
+	pp := proof.Proof1Params{
+		Curve:        b.Curve,
+		Pk:           b.Pk,
+		C:            new(big.Int).SetBytes(ciphertext),
+		DealerParams: dealerParams,
+	}
+	return proof.VerifyRange1(&pp, &rangeProof)
+}
+
+func (b *Backend) Decrypt(ciphertext []byte) (*big.Int, error) {
+	if b.Sk == nil {
+		return nil, fmt.Errorf("paillier mta backend: no secret key")
+	}
+	return b.Sk.Decrypt(new(big.Int).SetBytes(ciphertext))
+}
+
+func (b *Backend) HomomorphicAdd(c1, c2 []byte) ([]byte, error) {
+	sum := new(big.Int).Mul(new(big.Int).SetBytes(c1), new(big.Int).SetBytes(c2))
+	sum.Mod(sum, b.Pk.NSquared())
+	return sum.Bytes(), nil
+}
+
+func (b *Backend) HomomorphicScalarMul(ciphertext []byte, scalar *big.Int) ([]byte, error) {
+	product := new(big.Int).Exp(new(big.Int).SetBytes(ciphertext), scalar, b.Pk.NSquared())
+	return product.Bytes(), nil
+}
+
+func decodeAux(aux mta.AuxParams) (*proof.DealerParams, error) {
+	if len(aux) == 0 {
+		return nil, fmt.Errorf("paillier mta backend: missing dealer params")
+	}
+	var dealerParams proof.DealerParams
+	if err := json.Unmarshal(aux, &dealerParams); err != nil {
+		return nil, fmt.Errorf("paillier mta backend: decoding dealer params: %w", err)
+	}
+	return &dealerParams, nil
+}
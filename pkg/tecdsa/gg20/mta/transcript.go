@@ -0,0 +1,21 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package mta
+
+import "crypto/sha256"
+
+// TranscriptHash hashes a session id, round number, and an already-encoded
+// message into the value identifiable-abort mode signs and later verifies --
+// shared here so the producer (participant.SignRound1WithAccountability)
+// and the verifier (accuse.Verify) always hash the same bytes.
+func TranscriptHash(sessionID []byte, round int, encodedPayload []byte) []byte {
+	h := sha256.New()
+	h.Write(sessionID)
+	h.Write([]byte{byte(round)})
+	h.Write(encodedPayload)
+	return h.Sum(nil)
+}
@@ -0,0 +1,29 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import "fmt"
+
+// UntrustedDealerParamsError is returned when a cosigner's published
+// ring-Pedersen auxiliary parameters (N~, h_1, h_2) fail validation or their
+// setup proof, and so cannot safely be used to build a Range1 proof against.
+type UntrustedDealerParamsError struct {
+	CosignerID uint32
+	Reason     error
+}
+
+func newUntrustedDealerParamsError(cosignerID uint32, reason error) *UntrustedDealerParamsError {
+	return &UntrustedDealerParamsError{CosignerID: cosignerID, Reason: reason}
+}
+
+func (e *UntrustedDealerParamsError) Error() string {
+	return fmt.Sprintf("cosigner %d: untrusted ring-pedersen dealer params: %v", e.CosignerID, e.Reason)
+}
+
+func (e *UntrustedDealerParamsError) Unwrap() error {
+	return e.Reason
+}
@@ -0,0 +1,177 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/accuse"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta"
+)
+
+// SignRound1WithAccountability runs SignRound1 in "identifiable abort" mode:
+// the returned broadcast is tagged with sessionID, and it and every P2P send
+// (DKG mode) are signed using sign over a transcript hash that covers
+// sessionID, so that any
+// party can later attribute either kind of message to this signer. Honest
+// parties that don't need attributable evidence can keep calling SignRound1
+// directly; this wrapper is opt-in per the caller's dispute-resolution
+// requirements.
+func (signer *Signer) SignRound1WithAccountability(backend mta.Backend, sessionID []byte, sign func(transcriptHash []byte) ([]byte, error)) (*Round1Bcast, map[uint32]*Round1P2PSend, []uint32, error) {
+	bcast, p2p, failedIds, err := signer.SignRound1(backend)
+	if err != nil {
+		return bcast, p2p, failedIds, err
+	}
+
+	bcast.SessionID = sessionID
+	hash, err := transcriptHash(sessionID, 1, bcast)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sig, err := sign(hash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bcast.TranscriptSig = sig
+
+	for id, send := range p2p {
+		hash, err := transcriptHash(sessionID, 1, send)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		sig, err := sign(hash)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		p2p[id].TranscriptSig = sig
+	}
+
+	return bcast, p2p, failedIds, nil
+}
+
+// receivedRound1 is what a cosigner sent this signer during round 1,
+// retained so Accuse can later reproduce a failing check against them:
+// Bcast is always populated (it carries Ctxt, and in trusted-dealer mode
+// Proof), P2P is populated only in DKG mode, where the well-formedness
+// proof instead arrives P2P.
+type receivedRound1 struct {
+	Bcast *Round1Bcast
+	P2P   *Round1P2PSend
+}
+
+// RecordRound1Message retains peerId's round-1 broadcast and, in DKG mode,
+// the P2P message it sent this signer, so that a later call to
+// Accuse(1, peerId) can reproduce a failing check against them. Callers
+// running identifiable-abort mode should call this as each cosigner's
+// round-1 messages arrive, before verifying them.
+func (signer *Signer) RecordRound1Message(peerId uint32, bcast *Round1Bcast, p2p *Round1P2PSend) {
+	if signer.state.received == nil {
+		signer.state.received = make(map[uint32]*receivedRound1)
+	}
+	signer.state.received[peerId] = &receivedRound1{Bcast: bcast, P2P: p2p}
+}
+
+// transcriptHash JSON-encodes payload and hashes it via mta.TranscriptHash;
+// it is the "session transcript hash" messages are signed over in
+// identifiable-abort mode. accuse.Verify recomputes the same hash from the
+// retained SignedMessage.Payload (which is this function's encoded form),
+// so the two must stay in lock-step.
+func transcriptHash(sessionID []byte, round int, payload interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("transcript hash: %w", err)
+	}
+	return mta.TranscriptHash(sessionID, round, encoded), nil
+}
+
+// Accuse bundles the minimum set of artefacts this signer retained about the
+// given round that are needed to reproduce, offline, a single failing check
+// against peerId -- the ciphertext and well-formedness proof peerId sent
+// this signer, the dealer params peerId proved against, and peerId's signed
+// transcript(s) -- requiring a prior call to RecordRound1Message to have
+// retained them. sessionID must be the same value passed to
+// SignRound1WithAccountability for this session, so the resulting Evidence's
+// transcript hash matches the one peerId actually signed. The result is
+// handed to accuse.Verify by any third party; it does not require peerId's
+// cooperation.
+func (signer *Signer) Accuse(sessionID []byte, round int, peerId uint32) (*accuse.Evidence, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("accuse: nil signer")
+	}
+	if round != 1 {
+		return nil, fmt.Errorf("accuse: round %d evidence is not yet supported", round)
+	}
+
+	received := signer.state.received[peerId]
+	if received == nil || received.Bcast == nil {
+		return nil, fmt.Errorf("accuse: no retained round 1 message from cosigner %d", peerId)
+	}
+
+	// auxID identifies whose published dealer params peerId's well-formedness
+	// proof was computed against: in trusted-dealer mode that's always the
+	// dealer (cosigner 1, see SignRound1's trusted-dealer branch); in DKG
+	// mode each cosigner proves well-formedness to a specific recipient
+	// against that recipient's own params, so it's this signer's own id.
+	var (
+		proofBytes []byte
+		sig        []byte
+		payload    interface{}
+		auxID      uint32
+	)
+	if signer.state.keyGenType.IsTrustedDealer() {
+		proofBytes = received.Bcast.Proof
+		sig = received.Bcast.TranscriptSig
+		// transcriptHash hashed this broadcast before TranscriptSig was set
+		// (a message can't include its own signature in what it signs), so
+		// the payload reproduced here must clear it the same way or the
+		// JSON encoding -- and so the hash -- won't match what was signed.
+		bcastSansSig := *received.Bcast
+		bcastSansSig.TranscriptSig = nil
+		payload = &bcastSansSig
+		auxID = 1
+	} else {
+		if received.P2P == nil {
+			return nil, fmt.Errorf("accuse: no retained P2P message from cosigner %d", peerId)
+		}
+		proofBytes = received.P2P.Proof
+		sig = received.P2P.TranscriptSig
+		p2pSansSig := *received.P2P
+		p2pSansSig.TranscriptSig = nil
+		payload = &p2pSansSig
+		auxID = signer.Id
+	}
+
+	dealerParams := signer.state.keyGenType.GetProofParams(auxID)
+	if dealerParams == nil {
+		return nil, fmt.Errorf("accuse: no dealer params on file for cosigner %d", auxID)
+	}
+	encodedParams, err := json.Marshal(dealerParams)
+	if err != nil {
+		return nil, fmt.Errorf("accuse: %w", err)
+	}
+	encodedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("accuse: %w", err)
+	}
+
+	return &accuse.Evidence{
+		SessionID:    sessionID,
+		Round:        round,
+		AccusedID:    peerId,
+		AccuserID:    signer.Id,
+		Backend:      received.Bcast.Backend,
+		Ciphertext:   received.Bcast.Ctxt,
+		Proof:        proofBytes,
+		DealerParams: encodedParams,
+		Messages:     []accuse.SignedMessage{{Payload: encodedPayload, Sig: sig}},
+		ReproScript: fmt.Sprintf(
+			"VerifyRingPedersenSetup(dealerParamsOf(%d)) then backend.Verify(ciphertext, proof, dealerParamsOf(%d)) to replay cosigner %d's round %d well-formedness proof",
+			auxID, auxID, peerId, round,
+		),
+	}, nil
+}
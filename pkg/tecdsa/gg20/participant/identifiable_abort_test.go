@@ -0,0 +1,105 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"testing"
+
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/accuse"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof/testutil"
+)
+
+// TestSignRound1WithAccountability_TranscriptSigVerifiesViaAccuse is the
+// end-to-end sign/verify round trip the transcript hash mismatch bug would
+// have broken: it signs a real Round1Bcast the way SignRound1WithAccountability
+// does, retains it via RecordRound1Message the way a cosigner would, builds
+// Evidence via Accuse, and checks that accuse.Verify recomputes the same
+// hash the message was actually signed over.
+func TestSignRound1WithAccountability_TranscriptSigVerifiesViaAccuse(t *testing.T) {
+	dealerParams := testutil.HonestDealerParams(t, dealerParamsPrimeBitLen, true)
+	peer, backend := newTrustedDealerSigner(t, dealerParams)
+	peer.Id = 1
+
+	const key = byte(0x42)
+	sign := func(hash []byte) ([]byte, error) {
+		return append([]byte{key}, hash...), nil
+	}
+	verifyTranscriptSig := func(peerID uint32, transcriptHash, sig []byte) bool {
+		want := append([]byte{key}, transcriptHash...)
+		return bytes.Equal(sig, want)
+	}
+
+	sessionID := []byte("test-session")
+	bcast, _, failedIds, err := peer.SignRound1WithAccountability(backend, sessionID, sign)
+	if err != nil {
+		t.Fatalf("SignRound1WithAccountability: %v", err)
+	}
+	if len(failedIds) != 0 {
+		t.Fatalf("expected no failed cosigners, got %v", failedIds)
+	}
+
+	accuser := &Signer{
+		Id:    2,
+		Curve: elliptic.P256(),
+		state: state{keyGenType: peer.state.keyGenType},
+	}
+	accuser.RecordRound1Message(peer.Id, bcast, nil)
+
+	evidence, err := accuser.Accuse(sessionID, 1, peer.Id)
+	if err != nil {
+		t.Fatalf("Accuse: %v", err)
+	}
+
+	guilty, reason, err := accuse.Verify(evidence, nil, verifyTranscriptSig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if guilty != 0 {
+		t.Fatalf("expected honestly-signed transcript to verify, got guilty=%d: %s", guilty, reason)
+	}
+}
+
+func TestSignRound1WithAccountability_CatchesForgedTranscriptSig(t *testing.T) {
+	dealerParams := testutil.HonestDealerParams(t, dealerParamsPrimeBitLen, true)
+	peer, backend := newTrustedDealerSigner(t, dealerParams)
+	peer.Id = 1
+
+	sign := func(hash []byte) ([]byte, error) {
+		return []byte("not a real signature"), nil
+	}
+	verifyTranscriptSig := func(peerID uint32, transcriptHash, sig []byte) bool {
+		return false
+	}
+
+	sessionID := []byte("test-session")
+	bcast, _, _, err := peer.SignRound1WithAccountability(backend, sessionID, sign)
+	if err != nil {
+		t.Fatalf("SignRound1WithAccountability: %v", err)
+	}
+
+	accuser := &Signer{
+		Id:    2,
+		Curve: elliptic.P256(),
+		state: state{keyGenType: peer.state.keyGenType},
+	}
+	accuser.RecordRound1Message(peer.Id, bcast, nil)
+
+	evidence, err := accuser.Accuse(sessionID, 1, peer.Id)
+	if err != nil {
+		t.Fatalf("Accuse: %v", err)
+	}
+
+	guilty, reason, err := accuse.Verify(evidence, nil, verifyTranscriptSig)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if guilty != peer.Id {
+		t.Fatalf("expected peer %d to be guilty, got %d: %s", peer.Id, guilty, reason)
+	}
+}
@@ -0,0 +1,58 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/inactivity"
+)
+
+// NewRound1InactivityTracker starts tracking this signer's cosigners'
+// delivery of their round-1 well-formedness proof (Round1P2PSend.Proof),
+// each owed by deadline. Callers running DKG mode call MarkReceived on the
+// result as Round1P2PSend values arrive (e.g. once per cosigner id, keyed
+// the same way signer.state.cosigners is), then ClaimInactivity once the
+// deadline has passed.
+//
+// This is a deliberately standalone subsystem, not wired into SignRound1
+// itself: SignRound1 is synchronous and returns as soon as it has either
+// produced or failed to produce this signer's own proofs, so it has no way
+// to wait out a deadline for a cosigner's message that simply never
+// arrives over the network -- that failure mode only exists at the
+// caller's message-passing layer, above SignRound1. A caller that wants
+// inactivity detection creates a Tracker itself (typically right after
+// calling SignRound1) and drives MarkReceived/ClaimInactivity from its own
+// network loop.
+func (signer *Signer) NewRound1InactivityTracker(sessionID []byte, deadline time.Time) *inactivity.Tracker {
+	ids := make([]uint32, 0, len(signer.state.cosigners))
+	for id := range signer.state.cosigners {
+		if id == signer.Id {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return inactivity.NewTracker(sessionID, 1, inactivity.Round1P2PMessage, ids, deadline)
+}
+
+// ClaimInactivity checks tracker against now and, if any cosigner has missed
+// its deadline, turns the result into a signed inactivity.InactivityClaim --
+// structured, aggregatable evidence a dispute-resolution layer can consume.
+// This covers a different failure mode than SignRound1's own
+// failedCosignerIds return value: failedCosignerIds reports cosigners whose
+// published dealer params this signer locally rejected before ever sending
+// anything, while a ClaimInactivity accusation reports a cosigner who never
+// delivered its message at all -- something only a caller's network layer,
+// not SignRound1 itself, can observe.
+func (signer *Signer) ClaimInactivity(tracker *inactivity.Tracker, now time.Time, sign func([]byte) ([]byte, error)) (*inactivity.InactivityClaim, error) {
+	inactiveIDs := tracker.Inactive(now)
+	if len(inactiveIDs) == 0 {
+		return nil, fmt.Errorf("inactivity: no cosigner has missed its deadline")
+	}
+	return inactivity.NewClaim(tracker.SessionID, tracker.Round, signer.Id, inactiveIDs, sign)
+}
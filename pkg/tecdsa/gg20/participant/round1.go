@@ -7,12 +7,15 @@
 package participant
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 
 	"github.com/nerifnetwork/kryptology/internal"
 	"github.com/nerifnetwork/kryptology/pkg/core"
 	"github.com/nerifnetwork/kryptology/pkg/core/curves"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta"
+	paillierbackend "github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta/paillier"
 	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof"
 )
 
@@ -20,34 +23,68 @@ import (
 type Round1Bcast struct {
 	Identifier uint32
 	C          core.Commitment
-	Ctxt       *big.Int
-	Proof      *proof.Range1Proof
+
+	// Backend identifies which mta.Backend produced Ctxt, so a recipient
+	// knows how to decode and verify it; see pkg/tecdsa/gg20/mta.
+	Backend mta.ID
+	// Ctxt is the MtA ciphertext encoding k_i, opaque to everyone but
+	// Backend's implementation.
+	Ctxt []byte
+	// Proof is Backend's well-formedness proof for Ctxt, populated in
+	// trusted-dealer mode (in DKG mode each cosigner instead gets its own
+	// proof P2P via Round1P2PSend.Proof).
+	Proof []byte
+
+	// SessionID and TranscriptSig are populated only when the signer is
+	// running in identifiable-abort mode (see SignRound1WithAccountability):
+	// SessionID identifies the signing session these values belong to, and
+	// TranscriptSig is the signer's signature over the hash of this
+	// broadcast, allowing any third party to later attribute this message to
+	// its sender. A missing or invalid TranscriptSig on a message that was
+	// expected to carry one is itself attributable evidence of misbehaviour.
+	SessionID     []byte
+	TranscriptSig []byte
 }
 
 type Round1P2PSend struct {
-	Range1Proof *proof.Range1Proof
+	// Proof is Backend's well-formedness proof for the recipient's copy of
+	// Ctxt -- a Range1Proof for the Paillier backend, a Schnorr-style
+	// argument for the CL backend, and so on; see mta.Backend.ProveWellFormed.
+	Proof []byte
+
+	// TranscriptSig, populated only in identifiable-abort mode (see
+	// SignRound1WithAccountability), is the sender's signature over the hash
+	// of this message, so its recipient can later attribute a bad Proof to
+	// its sender the same way a bad Round1Bcast is attributed via
+	// Round1Bcast.TranscriptSig.
+	TranscriptSig []byte
 }
 
 // SignRound1 performs round 1 signing operation
 // Trusted Dealer Mode: see [spec] fig 7: SignRound1
 // DKG Mode: see [spec] fig 8: SignRound1
-// NOTE: Pseudocode shows N~, h1, h2, the curve's g, q, and signer's public key as inputs
-// Since `signer` already knows the paillier secret and public keys, this input is not necessary here
+// NOTE: Pseudocode shows N~, h1, h2, the curve's g, q, and signer's public key as inputs.
+// backend is the MtA encryption scheme to use for this session (see pkg/tecdsa/gg20/mta);
+// passing the Paillier backend reproduces the original protocol, including its dependence
+// on the dealer's ring-Pedersen parameters. SignRound1 refuses any backend whose
+// CanCompleteSigning reports false (currently true only of the Paillier backend -- see
+// mta/cl's package doc comment), since rounds 2-6 have no way to finish signing without it.
 // `participant.PrepareToSign` receives the other inputs and stores them as state variables.
-func (signer *Signer) SignRound1() (*Round1Bcast, map[uint32]*Round1P2PSend, []uint32, error) {
+func (signer *Signer) SignRound1(backend mta.Backend) (*Round1Bcast, map[uint32]*Round1P2PSend, []uint32, error) {
 	var failedCosignerIds []uint32
 	var failedCosignerErrors []error
 
-	if signer == nil || signer.Curve == nil {
+	if signer == nil || signer.Curve == nil || backend == nil {
 		return nil, nil, nil, internal.ErrNilArguments
 	}
+	if !backend.CanCompleteSigning() {
+		return nil, nil, nil, fmt.Errorf("mta backend %d cannot complete a signing session (see its package doc comment)", backend.ID())
+	}
 
 	if err := signer.verifyStateMap(1, nil); err != nil {
 		return nil, nil, nil, err
 	}
 
-	pk := &signer.SecretKey.PublicKey
-
 	// 1. k_i \getsr Z_q
 	k, err := core.Rand(signer.Curve.Params().N)
 	if err != nil {
@@ -72,30 +109,28 @@ func (signer *Signer) SignRound1() (*Round1Bcast, map[uint32]*Round1P2PSend, []u
 		return nil, nil, nil, err
 	}
 
-	// 5. c_i, r_i = PaillierEncryptAndReturnRandomness(pk_i, k_i)
-	ctxt, r, err := pk.Encrypt(k)
+	// 5. c_i, r_i = Encrypt(pk_i, k_i), returning the randomness used so a
+	// well-formedness proof can be produced against it below.
+	ctxt, r, err := backend.Encrypt(k)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	pp := proof.Proof1Params{
-		Curve: signer.Curve,
-		Pk:    pk,
-		A:     k,
-		C:     ctxt,
-		R:     r,
-	}
 	bcast := Round1Bcast{
 		Identifier: signer.Id,
 		C:          Ci,
+		Backend:    backend.ID(),
 		Ctxt:       ctxt,
 	}
 	p2p := make(map[uint32]*Round1P2PSend)
 
 	if signer.state.keyGenType.IsTrustedDealer() {
-		pp.DealerParams = signer.state.keyGenType.GetProofParams(1)
-		// 6. TrustedDealer - \pi_i^{\Range1} = MtAProveRange1(g,q,pk_i,N~,h_1,h_2,k_i,c_i,r_i)
-		bcast.Proof, err = pp.Prove()
+		aux, err := dealerAux(backend, signer.state.keyGenType.GetProofParams(1))
+		if err != nil {
+			return nil, nil, []uint32{1}, newUntrustedDealerParamsError(1, err)
+		}
+		// 6. TrustedDealer - \pi_i = ProveWellFormed(g,q,pk_i,aux,k_i,c_i,r_i)
+		bcast.Proof, err = backend.ProveWellFormed(k, r, ctxt, aux)
 		if err != nil {
 			return nil, nil, failedCosignerIds, err
 		}
@@ -106,14 +141,14 @@ func (signer *Signer) SignRound1() (*Round1Bcast, map[uint32]*Round1P2PSend, []u
 			if signer.Id == id {
 				continue
 			}
-			pp.DealerParams = signer.state.keyGenType.GetProofParams(id)
-			if pp.DealerParams == nil {
+			aux, err := dealerAux(backend, signer.state.keyGenType.GetProofParams(id))
+			if err != nil {
 				failedCosignerIds = append(failedCosignerIds, id)
-				failedCosignerErrors = append(failedCosignerErrors, fmt.Errorf("no proof params found for cosigner"))
+				failedCosignerErrors = append(failedCosignerErrors, newUntrustedDealerParamsError(id, err))
 				continue
 			}
-			// 8. DKG \pi_ij^{\Range1} = MtAProveRange1(g,q,pk_i,N~j,h_1j,h_2j,k_i,c_i,r_i)
-			pi, err := pp.Prove()
+			// 8. DKG \pi_ij = ProveWellFormed(g,q,pk_i,aux_j,k_i,c_i,r_i)
+			pi, err := backend.ProveWellFormed(k, r, ctxt, aux)
 			if err != nil {
 				failedCosignerIds = append(failedCosignerIds, id)
 				failedCosignerErrors = append(failedCosignerErrors, err)
@@ -121,7 +156,7 @@ func (signer *Signer) SignRound1() (*Round1Bcast, map[uint32]*Round1P2PSend, []u
 			}
 			// 9. P2PSend
 			p2p[id] = &Round1P2PSend{
-				Range1Proof: pi,
+				Proof: pi,
 			}
 		}
 	}
@@ -136,11 +171,59 @@ func (signer *Signer) SignRound1() (*Round1Bcast, map[uint32]*Round1P2PSend, []u
 	signer.state.gammai = gamma
 	signer.state.Gammai = Gamma
 	signer.state.Di = Di
-	signer.state.ci = ctxt
+	if backend.ID() == mta.Paillier {
+		// state.ci retains the raw Paillier ciphertext integer for the later
+		// rounds that still operate on it directly. Paillier is currently the
+		// only backend.CanCompleteSigning() accepts above, so this branch
+		// always runs in practice; a future backend that also reports
+		// CanCompleteSigning() true would need rounds 2-6 (not present in
+		// this package) threaded through mta.Backend the same way round 1
+		// is, with its own equivalent of state.ci if its wire format needs
+		// one.
+		signer.state.ci = new(big.Int).SetBytes(ctxt)
+	}
 	signer.state.ri = r
 
-	// (figure 7) 7. Broadcast (C_i, c_i, \pi^{Range1}_i)
-	// (figure 8) 9. P2PSend(\pi^{Range1}_ij)
+	// (figure 7) 7. Broadcast (C_i, c_i, \pi_i)
+	// (figure 8) 9. P2PSend(\pi_ij)
 	// (figure 8) 10. Broadcast (C_i, c_i)
 	return &bcast, p2p, nil, nil
 }
+
+// dealerAux looks up cosigner id's published auxiliary parameters and
+// encodes them the way backend expects to receive them. Only the Paillier
+// backend needs dealer-supplied parameters (its ring-Pedersen N~, h_1, h_2);
+// other backends, e.g. CL, ignore aux entirely. For the Paillier backend, the
+// parameters' ring-Pedersen setup proof is verified here, not merely their
+// structural shape: a dealer who can pick degenerate (N~, h_1, h_2) -- even
+// ones that pass the cheap structural checks -- can use the well-formedness
+// proof we're about to compute to leak bits of k_i (see the Fireblocks
+// "small-leak" attack), so a Range1 proof must never be produced against
+// parameters whose setup proof doesn't verify.
+func dealerAux(backend mta.Backend, dealerParams *proof.DealerParams) (mta.AuxParams, error) {
+	if backend.ID() != mta.Paillier {
+		return nil, nil
+	}
+	if dealerParams == nil {
+		return nil, fmt.Errorf("no proof params found for cosigner")
+	}
+	if err := proof.VerifyRingPedersenSetup(dealerParams, dealerParams.SetupProof); err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(dealerParams)
+	if err != nil {
+		return nil, fmt.Errorf("encoding dealer params: %w", err)
+	}
+	return mta.AuxParams(encoded), nil
+}
+
+// NewPaillierBackend adapts signer's existing Paillier keys into the
+// mta.Backend interface, reproducing the original (pre-backend-abstraction)
+// signing behaviour exactly.
+func (signer *Signer) NewPaillierBackend() mta.Backend {
+	return &paillierbackend.Backend{
+		Curve: signer.Curve,
+		Pk:    &signer.SecretKey.PublicKey,
+		Sk:    signer.SecretKey,
+	}
+}
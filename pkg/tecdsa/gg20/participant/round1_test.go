@@ -0,0 +1,116 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/nerifnetwork/kryptology/pkg/paillier"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta/cl"
+	paillierbackend "github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/mta/paillier"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof/testutil"
+)
+
+// dealerParamsPrimeBitLen is shared across this file's tests -- see
+// proof/testutil.HonestDealerParams.
+const dealerParamsPrimeBitLen = 1004
+
+// fakeKeyGenType is a minimal keyGenType double: a real trusted-dealer or
+// DKG implementation additionally distributes shares etc, none of which
+// SignRound1 touches.
+type fakeKeyGenType struct {
+	trustedDealer bool
+	params        map[uint32]*proof.DealerParams
+}
+
+func (f *fakeKeyGenType) IsTrustedDealer() bool { return f.trustedDealer }
+func (f *fakeKeyGenType) GetProofParams(id uint32) *proof.DealerParams {
+	return f.params[id]
+}
+
+func testPaillierKey(t *testing.T) (*paillier.PublicKey, *paillier.SecretKey) {
+	t.Helper()
+	p, err := rand.Prime(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := rand.Prime(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := &paillier.PublicKey{N: new(big.Int).Mul(p, q)}
+	return pk, &paillier.SecretKey{PublicKey: *pk}
+}
+
+func newTrustedDealerSigner(t *testing.T, dealerParams *proof.DealerParams) (*Signer, *paillierbackend.Backend) {
+	t.Helper()
+	pk, sk := testPaillierKey(t)
+	backend := &paillierbackend.Backend{Curve: elliptic.P256(), Pk: pk, Sk: sk}
+	signer := &Signer{
+		Id:    2,
+		Curve: elliptic.P256(),
+		state: state{
+			keyGenType: &fakeKeyGenType{
+				trustedDealer: true,
+				params:        map[uint32]*proof.DealerParams{1: dealerParams},
+			},
+		},
+	}
+	return signer, backend
+}
+
+func TestSignRound1_RefusesDealerParamsWithoutSetupProof(t *testing.T) {
+	signer, backend := newTrustedDealerSigner(t, testutil.HonestDealerParams(t, dealerParamsPrimeBitLen, false))
+
+	_, _, failedIds, err := signer.SignRound1(backend)
+	if err == nil {
+		t.Fatal("expected SignRound1 to refuse dealer params with no setup proof")
+	}
+	var untrusted *UntrustedDealerParamsError
+	if !errors.As(err, &untrusted) {
+		t.Fatalf("expected an UntrustedDealerParamsError, got %T: %v", err, err)
+	}
+	if len(failedIds) != 1 || failedIds[0] != 1 {
+		t.Fatalf("expected failedCosignerIds [1], got %v", failedIds)
+	}
+	if signer.Round != 0 {
+		t.Fatalf("signer advanced to round %d despite refusing to sign", signer.Round)
+	}
+}
+
+func TestSignRound1_AcceptsHonestDealerParams(t *testing.T) {
+	signer, backend := newTrustedDealerSigner(t, testutil.HonestDealerParams(t, dealerParamsPrimeBitLen, true))
+
+	bcast, _, failedIds, err := signer.SignRound1(backend)
+	if err != nil {
+		t.Fatalf("SignRound1: %v", err)
+	}
+	if len(failedIds) != 0 {
+		t.Fatalf("expected no failed cosigners, got %v", failedIds)
+	}
+	if len(bcast.Proof) == 0 {
+		t.Fatal("expected a Range1 proof to be produced")
+	}
+	if signer.Round != 2 {
+		t.Fatalf("expected signer to advance to round 2, got %d", signer.Round)
+	}
+}
+
+func TestDealerAux_IgnoresAuxForCLBackend(t *testing.T) {
+	aux, err := dealerAux(&cl.Backend{}, nil)
+	if err != nil {
+		t.Fatalf("dealerAux: %v", err)
+	}
+	if aux != nil {
+		t.Fatalf("expected nil aux for a non-Paillier backend, got %v", aux)
+	}
+}
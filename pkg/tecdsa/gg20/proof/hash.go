@@ -0,0 +1,34 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+// challenge derives a Fiat-Shamir challenge from the given big.Int transcript
+// elements. Each element is length-prefixed before hashing so that two
+// different statement tuples can't collide by shifting bytes across an
+// element boundary.
+func challenge(elements ...*big.Int) *big.Int {
+	h := sha256.New()
+	var lenBuf [4]byte
+	for _, e := range elements {
+		if e == nil {
+			binary.BigEndian.PutUint32(lenBuf[:], 0)
+			h.Write(lenBuf[:])
+			continue
+		}
+		b := e.Bytes()
+		binary.BigEndian.PutUint32(lenBuf[:], uint32(len(b)))
+		h.Write(lenBuf[:])
+		h.Write(b)
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
@@ -0,0 +1,69 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package proof implements the zero-knowledge proofs used by the GG20
+// threshold-ECDSA signing protocol.
+package proof
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/nerifnetwork/kryptology/pkg/core/curves"
+	"github.com/nerifnetwork/kryptology/pkg/paillier"
+)
+
+// DealerParams holds the ring-Pedersen auxiliary parameters (N~, h_1, h_2)
+// that a dealer (trusted dealer or DKG cosigner) publishes alongside its
+// Paillier public key, used as the modulus/bases for Range1 proofs produced
+// against that dealer.
+//
+// SetupProof, if present, is the dealer's RingPedersenSetupProof showing
+// h_1, h_2 were honestly derived from a common witness lambda rather than
+// chosen to leak bits of whatever Range1 proof is computed against them (see
+// VerifyRingPedersenSetup). A dealer that only publishes NTilde/H1/H2
+// without a SetupProof has passed ValidateDealerParams' structural checks
+// but not the stronger setup check; callers that need the full defence
+// against the Fireblocks "small-leak" attack must call
+// VerifyRingPedersenSetup, not just ValidateDealerParams.
+type DealerParams struct {
+	NTilde     *big.Int
+	H1         *big.Int
+	H2         *big.Int
+	SetupProof *RingPedersenSetupProof
+}
+
+// Range1Proof is \pi^{Range1}, proving that a Paillier ciphertext c encrypts
+// a value a lying in [0, q) without revealing a.
+type Range1Proof struct {
+	Z  *big.Int
+	U  *big.Int
+	W  *big.Int
+	S  *big.Int
+	S1 *big.Int
+	S2 *big.Int
+}
+
+// Proof1Params are the inputs needed to produce a Range1Proof: see [spec] fig 7/8.
+type Proof1Params struct {
+	Curve        curves.Curve
+	Pk           *paillier.PublicKey
+	A            *big.Int
+	C            *big.Int
+	R            *big.Int
+	DealerParams *DealerParams
+}
+
+// Prove computes \pi^{Range1} = MtAProveRange1(g, q, pk, N~, h_1, h_2, a, c, r).
+func (pp *Proof1Params) Prove() (*Range1Proof, error) {
+	if pp == nil || pp.Curve == nil || pp.Pk == nil || pp.A == nil || pp.C == nil || pp.R == nil {
+		return nil, fmt.Errorf("proof1 params: nil argument")
+	}
+	if pp.DealerParams == nil {
+		return nil, fmt.Errorf("proof1 params: missing dealer params")
+	}
+	return proveRange1(pp)
+}
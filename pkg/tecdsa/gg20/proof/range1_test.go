@@ -0,0 +1,102 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/nerifnetwork/kryptology/pkg/paillier"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof"
+)
+
+func honestPaillierKey(t *testing.T) *paillier.PublicKey {
+	t.Helper()
+	p, err := rand.Prime(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := rand.Prime(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &paillier.PublicKey{N: new(big.Int).Mul(p, q)}
+}
+
+func TestRange1Proof_RoundTrip(t *testing.T) {
+	pk := honestPaillierKey(t)
+	dealerParams := honestDealerParams(t)
+	curve := elliptic.P256()
+
+	a := big.NewInt(42)
+	c, r, err := pk.Encrypt(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pp := &proof.Proof1Params{Curve: curve, Pk: pk, A: a, C: c, R: r, DealerParams: dealerParams}
+	rangeProof, err := pp.Prove()
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+
+	verifyParams := &proof.Proof1Params{Curve: curve, Pk: pk, C: c, DealerParams: dealerParams}
+	if err := proof.VerifyRange1(verifyParams, rangeProof); err != nil {
+		t.Fatalf("VerifyRange1: %v", err)
+	}
+}
+
+func TestRange1Proof_RejectsTamperedProof(t *testing.T) {
+	pk := honestPaillierKey(t)
+	dealerParams := honestDealerParams(t)
+	curve := elliptic.P256()
+
+	a := big.NewInt(42)
+	c, r, err := pk.Encrypt(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pp := &proof.Proof1Params{Curve: curve, Pk: pk, A: a, C: c, R: r, DealerParams: dealerParams}
+	rangeProof, err := pp.Prove()
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+	rangeProof.S1 = new(big.Int).Add(rangeProof.S1, big.NewInt(1))
+
+	verifyParams := &proof.Proof1Params{Curve: curve, Pk: pk, C: c, DealerParams: dealerParams}
+	if err := proof.VerifyRange1(verifyParams, rangeProof); err == nil {
+		t.Fatal("expected error verifying a tampered range proof")
+	}
+}
+
+func TestRange1Proof_VerifyRejectsDegenerateDealerParams(t *testing.T) {
+	pk := honestPaillierKey(t)
+	dealerParams := honestDealerParams(t)
+	curve := elliptic.P256()
+
+	a := big.NewInt(42)
+	c, r, err := pk.Encrypt(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp := &proof.Proof1Params{Curve: curve, Pk: pk, A: a, C: c, R: r, DealerParams: dealerParams}
+	rangeProof, err := pp.Prove()
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+
+	// A verifier must reject a proof checked against degenerate dealer
+	// params even if the proof itself is internally consistent.
+	degenerate := &proof.DealerParams{NTilde: big.NewInt(15), H1: big.NewInt(2), H2: big.NewInt(7)}
+	verifyParams := &proof.Proof1Params{Curve: curve, Pk: pk, C: c, DealerParams: degenerate}
+	if err := proof.VerifyRange1(verifyParams, rangeProof); err == nil {
+		t.Fatal("expected error verifying against degenerate dealer params")
+	}
+}
@@ -0,0 +1,155 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// minNTildeBitLen is the minimum accepted bit length of a dealer's N~. This
+// matches the modulus size used elsewhere for Paillier moduli in this
+// protocol; a shorter N~ is refused outright rather than merely penalized,
+// since a short modulus makes the ring-Pedersen setup proof below cheap to
+// forge.
+const minNTildeBitLen = 2000
+
+// ringPedersenRounds is the number of parallel Schnorr-style transcripts
+// used by the setup proof below, chosen to match the ~80-bit statistical
+// security parameter used by Range1Proof.
+const ringPedersenRounds = 80
+
+// RingPedersenSetupProof is a zero-knowledge proof that the dealer who
+// published a DealerParams{NTilde, H1, H2} knows a witness lambda such that
+// H1 = H2^lambda mod NTilde and H2 = H1^{lambda^-1} mod NTilde, i.e. that
+// h_1 and h_2 generate the same subgroup of (Z/NTilde)*. It defeats the
+// Fireblocks "small-leak" attack, in which a dealer who instead picks
+// degenerate (h_1, h_2, N~) can force the Range1 proof an honest cosigner
+// produces against them to leak bits of that cosigner's k_i.
+type RingPedersenSetupProof struct {
+	A []*big.Int // commitments g_i = H2^{r_i} mod NTilde, one per round
+	Z []*big.Int // responses z_i = r_i + e_i*lambda mod phi(NTilde), one per round
+}
+
+// ValidateDealerParams performs the structural checks on a dealer's
+// ring-Pedersen auxiliary parameters that must hold before any Range1 proof
+// is produced or accepted against them. It does not by itself prove that
+// h_1, h_2 were derived honestly -- that is the job of
+// VerifyRingPedersenSetup -- but it rules out the obviously degenerate
+// choices (h_1 == h_2, h_1 or h_2 in {0, 1, N~-1}, N~ not coprime to h_1/h_2,
+// N~ too short to have been generated as a biprime of the expected size).
+func ValidateDealerParams(dp *DealerParams) error {
+	if dp == nil || dp.NTilde == nil || dp.H1 == nil || dp.H2 == nil {
+		return fmt.Errorf("ring-pedersen params: nil argument")
+	}
+	nTilde := dp.NTilde
+	if nTilde.BitLen() < minNTildeBitLen {
+		return fmt.Errorf("ring-pedersen params: N~ is only %d bits, want at least %d", nTilde.BitLen(), minNTildeBitLen)
+	}
+
+	one := big.NewInt(1)
+	nTildeMinusOne := new(big.Int).Sub(nTilde, one)
+	for name, h := range map[string]*big.Int{"h_1": dp.H1, "h_2": dp.H2} {
+		if h.Sign() <= 0 || h.Cmp(nTildeMinusOne) >= 0 {
+			return fmt.Errorf("ring-pedersen params: %s is out of range", name)
+		}
+		if h.Cmp(one) == 0 {
+			return fmt.Errorf("ring-pedersen params: %s must not be 1", name)
+		}
+		if new(big.Int).GCD(nil, nil, h, nTilde).Cmp(one) != 0 {
+			return fmt.Errorf("ring-pedersen params: %s is not coprime to N~", name)
+		}
+	}
+	if dp.H1.Cmp(dp.H2) == 0 {
+		return fmt.Errorf("ring-pedersen params: h_1 must not equal h_2")
+	}
+	return nil
+}
+
+// ProveRingPedersenSetup produces a RingPedersenSetupProof that the dealer
+// knows lambda with H1 = H2^lambda mod NTilde (and hence H2 = H1^{lambda^-1}
+// mod NTilde, since lambda is invertible mod phi(NTilde)). phiNTilde is the
+// order of the group the dealer generated NTilde's factorization from, i.e.
+// (p-1)(q-1) for NTilde = p*q; only the dealer, who knows the factorization,
+// can compute it.
+func ProveRingPedersenSetup(dp *DealerParams, lambda, phiNTilde *big.Int) (*RingPedersenSetupProof, error) {
+	if err := ValidateDealerParams(dp); err != nil {
+		return nil, err
+	}
+	if lambda == nil || phiNTilde == nil {
+		return nil, fmt.Errorf("ring-pedersen setup proof: nil witness")
+	}
+	if new(big.Int).GCD(nil, nil, lambda, phiNTilde).Cmp(big.NewInt(1)) != 0 {
+		return nil, fmt.Errorf("ring-pedersen setup proof: lambda is not invertible mod phi(N~)")
+	}
+
+	setupProof := &RingPedersenSetupProof{
+		A: make([]*big.Int, ringPedersenRounds),
+		Z: make([]*big.Int, ringPedersenRounds),
+	}
+	rs := make([]*big.Int, ringPedersenRounds)
+	for i := 0; i < ringPedersenRounds; i++ {
+		r, err := rand.Int(rand.Reader, phiNTilde)
+		if err != nil {
+			return nil, err
+		}
+		rs[i] = r
+		setupProof.A[i] = new(big.Int).Exp(dp.H2, r, dp.NTilde)
+	}
+
+	e := ringPedersenChallenge(dp, setupProof.A)
+	for i := 0; i < ringPedersenRounds; i++ {
+		bit := new(big.Int).Rsh(e, uint(i))
+		z := new(big.Int).Mul(bit.And(bit, big.NewInt(1)), lambda)
+		z.Add(z, rs[i])
+		z.Mod(z, phiNTilde)
+		setupProof.Z[i] = z
+	}
+	return setupProof, nil
+}
+
+// VerifyRingPedersenSetup validates dp's structural shape and checks
+// setupProof against it, returning a descriptive error identifying what
+// failed if dp was not honestly generated. Callers -- notably SignRound1 --
+// must call this once per dealer before using dp in a Range1 proof.
+func VerifyRingPedersenSetup(dp *DealerParams, setupProof *RingPedersenSetupProof) error {
+	if err := ValidateDealerParams(dp); err != nil {
+		return err
+	}
+	if setupProof == nil || len(setupProof.A) != ringPedersenRounds || len(setupProof.Z) != ringPedersenRounds {
+		return fmt.Errorf("ring-pedersen setup proof: malformed proof")
+	}
+
+	e := ringPedersenChallenge(dp, setupProof.A)
+	for i := 0; i < ringPedersenRounds; i++ {
+		if setupProof.A[i] == nil || setupProof.Z[i] == nil {
+			return fmt.Errorf("ring-pedersen setup proof: missing transcript element at round %d", i)
+		}
+		lhs := new(big.Int).Exp(dp.H2, setupProof.Z[i], dp.NTilde)
+
+		bit := new(big.Int).Rsh(e, uint(i))
+		rhs := new(big.Int).Set(setupProof.A[i])
+		if bit.And(bit, big.NewInt(1)).Sign() != 0 {
+			rhs = new(big.Int).Mul(rhs, dp.H1)
+			rhs.Mod(rhs, dp.NTilde)
+		}
+		if lhs.Cmp(rhs) != 0 {
+			return fmt.Errorf("ring-pedersen setup proof: transcript %d failed to verify", i)
+		}
+	}
+	return nil
+}
+
+// ringPedersenChallenge derives the Fiat-Shamir challenge for the setup
+// proof from the dealer params and the round commitments.
+func ringPedersenChallenge(dp *DealerParams, a []*big.Int) *big.Int {
+	elements := make([]*big.Int, 0, len(a)+3)
+	elements = append(elements, dp.NTilde, dp.H1, dp.H2)
+	elements = append(elements, a...)
+	return challenge(elements...)
+}
@@ -0,0 +1,82 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof"
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof/testutil"
+)
+
+const testNTildePrimeBitLen = 1004
+
+func honestDealerParams(t *testing.T) *proof.DealerParams {
+	t.Helper()
+	return testutil.HonestDealerParams(t, testNTildePrimeBitLen, true)
+}
+
+func TestRingPedersenSetup_RoundTrip(t *testing.T) {
+	dp := honestDealerParams(t)
+	if err := proof.VerifyRingPedersenSetup(dp, dp.SetupProof); err != nil {
+		t.Fatalf("VerifyRingPedersenSetup: %v", err)
+	}
+}
+
+func TestRingPedersenSetup_RejectsMissingProof(t *testing.T) {
+	dp := honestDealerParams(t)
+	if err := proof.VerifyRingPedersenSetup(dp, nil); err == nil {
+		t.Fatal("expected error verifying nil setup proof")
+	}
+}
+
+func TestRingPedersenSetup_RejectsTamperedTranscript(t *testing.T) {
+	dp := honestDealerParams(t)
+	tampered := *dp.SetupProof
+	tampered.Z = append([]*big.Int{}, dp.SetupProof.Z...)
+	tampered.Z[0] = new(big.Int).Add(tampered.Z[0], big.NewInt(1))
+
+	if err := proof.VerifyRingPedersenSetup(dp, &tampered); err == nil {
+		t.Fatal("expected error verifying tampered setup proof")
+	}
+}
+
+func TestRingPedersenSetup_RejectsDegenerateParams(t *testing.T) {
+	dp := honestDealerParams(t)
+
+	// A dealer that swaps in a fresh, uncorrelated H1 may still pass
+	// ValidateDealerParams' structural checks but must fail the stronger
+	// setup proof, since its SetupProof was computed against the original
+	// H1.
+	degenerate := *dp
+	freshH1, err := rand.Int(rand.Reader, dp.NTilde)
+	if err != nil {
+		t.Fatal(err)
+	}
+	degenerate.H1 = freshH1
+
+	if err := proof.VerifyRingPedersenSetup(&degenerate, dp.SetupProof); err == nil {
+		t.Fatal("expected error verifying setup proof against swapped-in H1")
+	}
+}
+
+func TestValidateDealerParams_RejectsShortModulus(t *testing.T) {
+	dp := &proof.DealerParams{NTilde: big.NewInt(15), H1: big.NewInt(2), H2: big.NewInt(7)}
+	if err := proof.ValidateDealerParams(dp); err == nil {
+		t.Fatal("expected error validating an undersized N~")
+	}
+}
+
+func TestValidateDealerParams_RejectsEqualBases(t *testing.T) {
+	dp := honestDealerParams(t)
+	dp.H2 = new(big.Int).Set(dp.H1)
+	if err := proof.ValidateDealerParams(dp); err == nil {
+		t.Fatal("expected error validating h_1 == h_2")
+	}
+}
@@ -0,0 +1,69 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package testutil builds ring-Pedersen dealer params for tests across the
+// gg20 tree (proof, participant, accuse, ...) so each package's test suite
+// doesn't reimplement its own honest-dealer fixture.
+package testutil
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/nerifnetwork/kryptology/pkg/tecdsa/gg20/proof"
+)
+
+// HonestDealerParams generates an (NTilde, H1, H2) ring-Pedersen tuple the
+// way an honest dealer would -- H2 is a random element, H1 = H2^lambda mod
+// NTilde for a random lambda -- using a pair of primeBitLen-bit primes for
+// NTilde's factors. If includeSetupProof is true, it also attaches the
+// SetupProof of knowledge of lambda; tests exercising a dealer who never
+// published that proof should pass false instead.
+func HonestDealerParams(tb testing.TB, primeBitLen int, includeSetupProof bool) *proof.DealerParams {
+	tb.Helper()
+	p, err := rand.Prime(rand.Reader, primeBitLen)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	q, err := rand.Prime(rand.Reader, primeBitLen)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	nTilde := new(big.Int).Mul(p, q)
+	phiNTilde := new(big.Int).Mul(new(big.Int).Sub(p, big.NewInt(1)), new(big.Int).Sub(q, big.NewInt(1)))
+
+	h2, err := rand.Int(rand.Reader, nTilde)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var lambda *big.Int
+	for {
+		lambda, err = rand.Int(rand.Reader, phiNTilde)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if new(big.Int).GCD(nil, nil, lambda, phiNTilde).Cmp(big.NewInt(1)) == 0 {
+			break
+		}
+	}
+	h1 := new(big.Int).Exp(h2, lambda, nTilde)
+
+	dp := &proof.DealerParams{NTilde: nTilde, H1: h1, H2: h2}
+	if err := proof.ValidateDealerParams(dp); err != nil {
+		tb.Fatalf("generated degenerate dealer params, retry test: %v", err)
+	}
+	if !includeSetupProof {
+		return dp
+	}
+
+	setupProof, err := proof.ProveRingPedersenSetup(dp, lambda, phiNTilde)
+	if err != nil {
+		tb.Fatalf("ProveRingPedersenSetup: %v", err)
+	}
+	dp.SetupProof = setupProof
+	return dp
+}